@@ -0,0 +1,159 @@
+package Motor
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ldmberman/GoEV3/utilities"
+)
+
+const (
+	countPerRotFD = "count_per_rot"
+	maxSpeedFD    = "max_speed"
+)
+
+// MotorState is a structured view of the space-separated tokens reported
+// by the state sysfs attribute.
+type MotorState struct {
+	Running    bool
+	Ramping    bool
+	Holding    bool
+	Overloaded bool
+	Stalled    bool
+}
+
+// ReadState reads and parses the state of the motor at port.
+func ReadState(port OutPort) (MotorState, error) {
+	raw, err := GetState(port)
+	if err != nil {
+		return MotorState{}, err
+	}
+	return parseState(raw), nil
+}
+
+func parseState(raw string) MotorState {
+	var s MotorState
+	for _, token := range strings.Fields(raw) {
+		switch token {
+		case "running":
+			s.Running = true
+		case "ramping":
+			s.Ramping = true
+		case "holding":
+			s.Holding = true
+		case "overloaded":
+			s.Overloaded = true
+		case "stalled":
+			s.Stalled = true
+		}
+	}
+	return s
+}
+
+// CountPerRot reads the number of tacho counts per rotation reported by the
+// motor at port, so callers can convert between degrees, rotations and
+// speed setpoints portably across large and medium motors.
+func CountPerRot(port OutPort) (int, error) {
+	folder, err := resolveFolder(port)
+	if err != nil {
+		return 0, err
+	}
+	return int(utilities.ReadInt32Value(folder, countPerRotFD)), nil
+}
+
+// MaxSpeed reads the maximum speed setpoint supported by the motor at port.
+func MaxSpeed(port OutPort) (int, error) {
+	folder, err := resolveFolder(port)
+	if err != nil {
+		return 0, err
+	}
+	return int(utilities.ReadInt32Value(folder, maxSpeedFD)), nil
+}
+
+// StallEvent describes a stall detected by a StallDetector.
+type StallEvent struct {
+	Port  OutPort
+	Speed int16
+	Power int16
+}
+
+// StallDetector watches a motor for a commanded run whose actual speed
+// stays below SpeedThreshold while its duty cycle saturates above
+// PowerThreshold for at least Window, and reports it on Events.
+type StallDetector struct {
+	SpeedThreshold int16
+	PowerThreshold int16
+	Window         time.Duration
+
+	Events chan StallEvent
+
+	stop chan struct{}
+}
+
+// NewStallDetector creates a StallDetector with an unbuffered Events channel.
+func NewStallDetector(speedThreshold, powerThreshold int16, window time.Duration) *StallDetector {
+	return &StallDetector{
+		SpeedThreshold: speedThreshold,
+		PowerThreshold: powerThreshold,
+		Window:         window,
+		Events:         make(chan StallEvent),
+	}
+}
+
+// Watch samples the motor at port's speed and power every sampleRate until
+// Stop is called, firing an event on Events whenever the motor stays below
+// SpeedThreshold with power at or above PowerThreshold for Window. The send
+// on Events does not block: an event is dropped if nothing is receiving on
+// Events at that moment.
+func (d *StallDetector) Watch(port OutPort, sampleRate time.Duration) {
+	d.stop = make(chan struct{})
+
+	go func() {
+		var below time.Duration
+		ticker := time.NewTicker(sampleRate)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-d.stop:
+				return
+			case <-ticker.C:
+				speed, err := CurrentSpeed(port)
+				if err != nil {
+					continue
+				}
+				power, err := CurrentPower(port)
+				if err != nil {
+					continue
+				}
+
+				if absInt16(speed) < d.SpeedThreshold && absInt16(power) >= d.PowerThreshold {
+					below += sampleRate
+					if below >= d.Window {
+						select {
+						case d.Events <- StallEvent{Port: port, Speed: speed, Power: power}:
+						default:
+						}
+						below = 0
+					}
+				} else {
+					below = 0
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops watching the motor.
+func (d *StallDetector) Stop() {
+	if d.stop != nil {
+		close(d.stop)
+	}
+}
+
+func absInt16(v int16) int16 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
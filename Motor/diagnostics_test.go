@@ -0,0 +1,33 @@
+package Motor
+
+import "testing"
+
+func TestParseState(t *testing.T) {
+	s := parseState("running holding")
+	want := MotorState{Running: true, Holding: true}
+	if s != want {
+		t.Fatalf("parseState(%q) = %+v, want %+v", "running holding", s, want)
+	}
+}
+
+func TestParseStateAllFlags(t *testing.T) {
+	s := parseState("running ramping holding overloaded stalled")
+	want := MotorState{Running: true, Ramping: true, Holding: true, Overloaded: true, Stalled: true}
+	if s != want {
+		t.Fatalf("parseState(...) = %+v, want %+v", s, want)
+	}
+}
+
+func TestParseStateEmpty(t *testing.T) {
+	if s := parseState(""); s != (MotorState{}) {
+		t.Fatalf("parseState(\"\") = %+v, want zero value", s)
+	}
+}
+
+func TestParseStateIgnoresUnknownTokens(t *testing.T) {
+	s := parseState("running some-future-flag")
+	want := MotorState{Running: true}
+	if s != want {
+		t.Fatalf("parseState(...) = %+v, want %+v", s, want)
+	}
+}
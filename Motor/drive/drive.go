@@ -0,0 +1,144 @@
+// Provides gamepad-style tank and arcade teleop drives on top of the Motor
+// package. The drives accept plain axis values in [-1, 1] from the caller,
+// so they don't depend on any specific input library - wire them to evdev,
+// a network socket, or an HTTP endpoint as needed.
+package drive
+
+import "github.com/ldmberman/GoEV3/Motor"
+
+// Drive is implemented by TankDrive and ArcadeDrive.
+type Drive interface {
+	// Update drives the motors from normalized joystick coordinates in
+	// [-1, 1].
+	Update(x, y float64) error
+	// Stop stops both motors.
+	Stop() error
+}
+
+var (
+	_ Drive = (*TankDrive)(nil)
+	_ Drive = (*ArcadeDrive)(nil)
+)
+
+// TankDrive maps each stick axis directly to one motor: x drives Left and
+// y drives Right.
+type TankDrive struct {
+	Left, Right Motor.OutPort
+	MaxSpeed    int16
+
+	// Deadzone is the radius around zero, in [0, 1], within which an axis
+	// value is treated as zero.
+	Deadzone float64
+	// SlewRate bounds the maximum change in commanded speed per Update
+	// call, to protect the gearboxes. Zero means unbounded.
+	SlewRate int16
+
+	leftSpeed, rightSpeed int16
+}
+
+// Update drives Left from x and Right from y.
+func (t *TankDrive) Update(x, y float64) error {
+	t.leftSpeed = slewLimit(t.leftSpeed, axisToSpeed(x, t.Deadzone, t.MaxSpeed), t.SlewRate)
+	t.rightSpeed = slewLimit(t.rightSpeed, axisToSpeed(y, t.Deadzone, t.MaxSpeed), t.SlewRate)
+
+	if err := Motor.Run(t.Left, t.leftSpeed); err != nil {
+		return err
+	}
+	return Motor.Run(t.Right, t.rightSpeed)
+}
+
+// Stop stops both motors.
+func (t *TankDrive) Stop() error {
+	t.leftSpeed, t.rightSpeed = 0, 0
+	if err := Motor.Stop(t.Left); err != nil {
+		return err
+	}
+	return Motor.Stop(t.Right)
+}
+
+// ArcadeDrive mixes a single stick's throttle (y) and turn (x) axes into
+// left and right motor speeds: left = clamp(y+x), right = clamp(y-x), with
+// square-scaling applied to each axis first to soften the response near
+// center.
+type ArcadeDrive struct {
+	Left, Right Motor.OutPort
+	MaxSpeed    int16
+
+	// Deadzone is the radius around zero, in [0, 1], within which an axis
+	// value is treated as zero.
+	Deadzone float64
+	// SlewRate bounds the maximum change in commanded speed per Update
+	// call, to protect the gearboxes. Zero means unbounded.
+	SlewRate int16
+
+	leftSpeed, rightSpeed int16
+}
+
+// Update drives both motors from throttle y and turn x.
+func (a *ArcadeDrive) Update(x, y float64) error {
+	x = squareScale(applyDeadzone(clampAxis(x), a.Deadzone))
+	y = squareScale(applyDeadzone(clampAxis(y), a.Deadzone))
+
+	leftTarget := int16(clampAxis(y+x) * float64(a.MaxSpeed))
+	rightTarget := int16(clampAxis(y-x) * float64(a.MaxSpeed))
+
+	a.leftSpeed = slewLimit(a.leftSpeed, leftTarget, a.SlewRate)
+	a.rightSpeed = slewLimit(a.rightSpeed, rightTarget, a.SlewRate)
+
+	if err := Motor.Run(a.Left, a.leftSpeed); err != nil {
+		return err
+	}
+	return Motor.Run(a.Right, a.rightSpeed)
+}
+
+// Stop stops both motors.
+func (a *ArcadeDrive) Stop() error {
+	a.leftSpeed, a.rightSpeed = 0, 0
+	if err := Motor.Stop(a.Left); err != nil {
+		return err
+	}
+	return Motor.Stop(a.Right)
+}
+
+func axisToSpeed(v, deadzone float64, maxSpeed int16) int16 {
+	v = applyDeadzone(clampAxis(v), deadzone)
+	return int16(v * float64(maxSpeed))
+}
+
+func applyDeadzone(v, deadzone float64) float64 {
+	if v > -deadzone && v < deadzone {
+		return 0
+	}
+	return v
+}
+
+func clampAxis(v float64) float64 {
+	if v > 1 {
+		return 1
+	}
+	if v < -1 {
+		return -1
+	}
+	return v
+}
+
+func squareScale(v float64) float64 {
+	if v < 0 {
+		return -(v * v)
+	}
+	return v * v
+}
+
+func slewLimit(prev, target, maxDelta int16) int16 {
+	if maxDelta <= 0 {
+		return target
+	}
+	delta := target - prev
+	if delta > maxDelta {
+		return prev + maxDelta
+	}
+	if delta < -maxDelta {
+		return prev - maxDelta
+	}
+	return target
+}
@@ -0,0 +1,57 @@
+package drive
+
+import "testing"
+
+func TestApplyDeadzone(t *testing.T) {
+	if v := applyDeadzone(0.05, 0.1); v != 0 {
+		t.Errorf("applyDeadzone(0.05, 0.1) = %v, want 0", v)
+	}
+	if v := applyDeadzone(0.5, 0.1); v != 0.5 {
+		t.Errorf("applyDeadzone(0.5, 0.1) = %v, want 0.5", v)
+	}
+	if v := applyDeadzone(-0.05, 0.1); v != 0 {
+		t.Errorf("applyDeadzone(-0.05, 0.1) = %v, want 0", v)
+	}
+}
+
+func TestClampAxis(t *testing.T) {
+	if v := clampAxis(1.5); v != 1 {
+		t.Errorf("clampAxis(1.5) = %v, want 1", v)
+	}
+	if v := clampAxis(-1.5); v != -1 {
+		t.Errorf("clampAxis(-1.5) = %v, want -1", v)
+	}
+	if v := clampAxis(0.3); v != 0.3 {
+		t.Errorf("clampAxis(0.3) = %v, want 0.3", v)
+	}
+}
+
+func TestSquareScale(t *testing.T) {
+	if v := squareScale(0.5); v != 0.25 {
+		t.Errorf("squareScale(0.5) = %v, want 0.25", v)
+	}
+	if v := squareScale(-0.5); v != -0.25 {
+		t.Errorf("squareScale(-0.5) = %v, want -0.25", v)
+	}
+}
+
+func TestSlewLimit(t *testing.T) {
+	if v := slewLimit(0, 100, 10); v != 10 {
+		t.Errorf("slewLimit(0, 100, 10) = %v, want 10", v)
+	}
+	if v := slewLimit(0, 100, 0); v != 100 {
+		t.Errorf("slewLimit(0, 100, 0) = %v, want 100 (unbounded)", v)
+	}
+	if v := slewLimit(50, 0, 10); v != 40 {
+		t.Errorf("slewLimit(50, 0, 10) = %v, want 40", v)
+	}
+}
+
+func TestAxisToSpeed(t *testing.T) {
+	if v := axisToSpeed(1, 0.1, 100); v != 100 {
+		t.Errorf("axisToSpeed(1, 0.1, 100) = %v, want 100", v)
+	}
+	if v := axisToSpeed(0.05, 0.1, 100); v != 0 {
+		t.Errorf("axisToSpeed(0.05, 0.1, 100) = %v, want 0 (within deadzone)", v)
+	}
+}
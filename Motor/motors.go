@@ -2,9 +2,10 @@
 package Motor
 
 import (
-	"log"
+	"fmt"
 	"os"
 	"path"
+	"sync"
 
 	"github.com/ldmberman/GoEV3/utilities"
 )
@@ -36,30 +37,88 @@ const (
 	stateGetter      = "state"
 )
 
-func findFolder(port OutPort) string {
+// Motor is a handle to a motor whose sysfs folder has already been
+// resolved, so that repeated operations don't each re-scan
+// /sys/class/tacho-motor for the matching port.
+type Motor struct {
+	port   OutPort
+	folder string
+}
+
+// Open resolves the sysfs folder for the motor connected to port and
+// returns a handle bound to it. The folder is resolved once and cached,
+// both on the handle and for subsequent package-level calls for the same
+// port.
+//
+// This removes the log.Fatal that used to happen while resolving a
+// motor's folder (e.g. no motor attached to port, or the tacho-motor tree
+// not mounted yet). Reads and writes to individual sysfs attributes still
+// go through utilities.ReadXxxValue/WriteXxxValue, which don't return
+// errors and fatal internally on I/O failure, so a transient failure
+// there can still bring the process down.
+func Open(port OutPort) (*Motor, error) {
+	folder, err := resolveFolder(port)
+	if err != nil {
+		return nil, err
+	}
+	return &Motor{port: port, folder: folder}, nil
+}
+
+var (
+	folderCacheMu sync.Mutex
+	folderCache   = map[OutPort]string{}
+)
+
+// resolveFolder returns the sysfs folder for port, scanning
+// rootMotorPath only the first time it's asked about a given port.
+func resolveFolder(port OutPort) (string, error) {
+	folderCacheMu.Lock()
+	defer folderCacheMu.Unlock()
+
+	if folder, ok := folderCache[port]; ok {
+		return folder, nil
+	}
+
+	folder, err := findFolder(port)
+	if err != nil {
+		return "", err
+	}
+
+	folderCache[port] = folder
+	return folder, nil
+}
+
+func findFolder(port OutPort) (string, error) {
 	if _, err := os.Stat(rootMotorPath); os.IsNotExist(err) {
-		log.Fatal("There are no motors connected")
+		return "", fmt.Errorf("there are no motors connected")
 	}
 
-	rootMotorFolder, _ := os.Open(rootMotorPath)
-	motorFolders, _ := rootMotorFolder.Readdir(-1)
+	rootMotorFolder, err := os.Open(rootMotorPath)
+	if err != nil {
+		return "", err
+	}
+	defer rootMotorFolder.Close()
+
+	motorFolders, err := rootMotorFolder.Readdir(-1)
+	if err != nil {
+		return "", err
+	}
 	if len(motorFolders) == 0 {
-		log.Fatal("There are no motors connected")
+		return "", fmt.Errorf("there are no motors connected")
 	}
 
 	for _, folderInfo := range motorFolders {
 		folder := folderInfo.Name()
 		motorPort := utilities.ReadStringValue(path.Join(rootMotorPath, folder), portFD)
 		if motorPort == string(port) {
-			return path.Join(rootMotorPath, folder)
+			return path.Join(rootMotorPath, folder), nil
 		}
 	}
 
-	log.Fatal("No motor is connected to port ", port)
-	return ""
+	return "", fmt.Errorf("no motor is connected to port %s", port)
 }
 
-// Runs the motor at the given port.
+// Run runs the motor at the given port.
 // The meaning of `speed` parameter depends on whether the regulation mode is turned on or off.
 //
 // When the regulation mode is off (by default) `speed` ranges from -100 to 100 and
@@ -72,66 +131,189 @@ func findFolder(port OutPort) string {
 // which ranges from about -1000 to 1000. The actual range depends on the type of the motor - see ev3dev docs.
 //
 // Negative values indicate reverse motion regardless of the regulation mode.
-func Run(port OutPort, speed int16) {
-	folder := findFolder(port)
-	utilities.WriteIntValue(folder, speedSetterFD, int64(speed))
-	utilities.WriteStringValue(folder, runFD, "run-forever")
+func Run(port OutPort, speed int16) error {
+	m, err := Open(port)
+	if err != nil {
+		return err
+	}
+	return m.Run(speed)
+}
+
+func RunToAbsPosition(port OutPort, speed int16, porision int16) error {
+	m, err := Open(port)
+	if err != nil {
+		return err
+	}
+	return m.RunToAbsPosition(speed, porision)
+}
+
+func Reset(port OutPort) error {
+	m, err := Open(port)
+	if err != nil {
+		return err
+	}
+	return m.Reset()
+}
+
+// Stop stops the motor at the given port.
+func Stop(port OutPort) error {
+	m, err := Open(port)
+	if err != nil {
+		return err
+	}
+	return m.Stop()
+}
+
+// CurrentSpeed reads the operating speed of the motor at the given port.
+func CurrentSpeed(port OutPort) (int16, error) {
+	m, err := Open(port)
+	if err != nil {
+		return 0, err
+	}
+	return m.CurrentSpeed()
 }
 
-func RunToAbsPosition(port OutPort, speed int16, porision int16) {
-	folder := findFolder(port)
-	utilities.WriteIntValue(folder, positionSetterFD, int64(porision))
-	utilities.WriteIntValue(folder, speedSetterFD, int64(speed))
-	utilities.WriteStringValue(folder, stopActionFD, "hold")
-	utilities.WriteStringValue(folder, runFD, "run-to-abs-pos")
+// CurrentPower reads the operating power of the motor at the given port.
+func CurrentPower(port OutPort) (int16, error) {
+	m, err := Open(port)
+	if err != nil {
+		return 0, err
+	}
+	return m.CurrentPower()
 }
 
-func Reset(port OutPort) {
-	utilities.WriteStringValue(findFolder(port), runFD, "reset")
+// EnableBrakeMode enables brake mode, causing the motor at the given port to brake to stops.
+func EnableBrakeMode(port OutPort) error {
+	m, err := Open(port)
+	if err != nil {
+		return err
+	}
+	return m.EnableBrakeMode()
 }
 
-// Stops the motor at the given port.
-func Stop(port OutPort) {
-	utilities.WriteStringValue(findFolder(port), runFD, "stop")
+// DisableBrakeMode disables brake mode, causing the motor at the given port to coast to stops. Brake mode is off by default.
+func DisableBrakeMode(port OutPort) error {
+	m, err := Open(port)
+	if err != nil {
+		return err
+	}
+	return m.DisableBrakeMode()
 }
 
-// Reads the operating speed of the motor at the given port.
-func CurrentSpeed(port OutPort) int16 {
-	return utilities.ReadInt16Value(findFolder(port), speedGetterFD)
+// CurrentPosition reads the position of the motor at the given port.
+func CurrentPosition(port OutPort) (int32, error) {
+	m, err := Open(port)
+	if err != nil {
+		return 0, err
+	}
+	return m.CurrentPosition()
 }
 
-// Reads the operating power of the motor at the given port.
-func CurrentPower(port OutPort) int16 {
-	return utilities.ReadInt16Value(findFolder(port), powerGetterFD)
+// InitializePosition sets the position of the motor at the given port.
+func InitializePosition(port OutPort, value int32) error {
+	m, err := Open(port)
+	if err != nil {
+		return err
+	}
+	return m.InitializePosition(value)
 }
 
-// Enables brake mode, causing the motor at the given port to brake to stops.
-func EnableBrakeMode(port OutPort) {
-	utilities.WriteStringValue(findFolder(port), stopModeFD, "brake")
+func HoldStopAction(port OutPort) error {
+	m, err := Open(port)
+	if err != nil {
+		return err
+	}
+	return m.HoldStopAction()
 }
 
-// Disables brake mode, causing the motor at the given port to coast to stops. Brake mode is off by default.
-func DisableBrakeMode(port OutPort) {
-	utilities.WriteStringValue(findFolder(port), stopModeFD, "coast")
+func CoastStopAction(port OutPort) error {
+	m, err := Open(port)
+	if err != nil {
+		return err
+	}
+	return m.CoastStopAction()
+}
+
+func GetState(port OutPort) (string, error) {
+	m, err := Open(port)
+	if err != nil {
+		return "", err
+	}
+	return m.State()
 }
 
-// Reads the position of the motor at the given port.
-func CurrentPosition(port OutPort) int32 {
-	return utilities.ReadInt32Value(findFolder(port), positionFD)
+// Run runs the motor. See the package-level Run for details on speed.
+func (m *Motor) Run(speed int16) error {
+	utilities.WriteIntValue(m.folder, speedSetterFD, int64(speed))
+	utilities.WriteStringValue(m.folder, runFD, "run-forever")
+	return nil
 }
 
-// Set the position of the motor at the given port.
-func InitializePosition(port OutPort, value int32) {
-	utilities.WriteIntValue(findFolder(port), positionFD, int64(value))
+// RunToAbsPosition runs the motor to the given absolute position.
+func (m *Motor) RunToAbsPosition(speed int16, position int16) error {
+	utilities.WriteIntValue(m.folder, positionSetterFD, int64(position))
+	utilities.WriteIntValue(m.folder, speedSetterFD, int64(speed))
+	utilities.WriteStringValue(m.folder, stopActionFD, "hold")
+	utilities.WriteStringValue(m.folder, runFD, "run-to-abs-pos")
+	return nil
 }
 
-func HoldStopAction(port OutPort) {
-	utilities.WriteStringValue(findFolder(port), stopActionFD, "hold")
+// Reset resets the motor.
+func (m *Motor) Reset() error {
+	utilities.WriteStringValue(m.folder, runFD, "reset")
+	return nil
 }
 
-func CoastStopAction(port OutPort) {
-	utilities.WriteStringValue(findFolder(port), stopActionFD, "coast")
+// Stop stops the motor.
+func (m *Motor) Stop() error {
+	utilities.WriteStringValue(m.folder, runFD, "stop")
+	return nil
 }
-func GetState(port OutPort) string {
-	return utilities.ReadStringValue(findFolder(port), stateGetter)
+
+// CurrentSpeed reads the operating speed of the motor.
+func (m *Motor) CurrentSpeed() (int16, error) {
+	return utilities.ReadInt16Value(m.folder, speedGetterFD), nil
+}
+
+// CurrentPower reads the operating power of the motor.
+func (m *Motor) CurrentPower() (int16, error) {
+	return utilities.ReadInt16Value(m.folder, powerGetterFD), nil
+}
+
+// EnableBrakeMode enables brake mode, causing the motor to brake to stops.
+func (m *Motor) EnableBrakeMode() error {
+	utilities.WriteStringValue(m.folder, stopModeFD, "brake")
+	return nil
+}
+
+// DisableBrakeMode disables brake mode, causing the motor to coast to stops. Brake mode is off by default.
+func (m *Motor) DisableBrakeMode() error {
+	utilities.WriteStringValue(m.folder, stopModeFD, "coast")
+	return nil
+}
+
+// CurrentPosition reads the position of the motor.
+func (m *Motor) CurrentPosition() (int32, error) {
+	return utilities.ReadInt32Value(m.folder, positionFD), nil
+}
+
+// InitializePosition sets the position of the motor.
+func (m *Motor) InitializePosition(value int32) error {
+	utilities.WriteIntValue(m.folder, positionFD, int64(value))
+	return nil
+}
+
+func (m *Motor) HoldStopAction() error {
+	utilities.WriteStringValue(m.folder, stopActionFD, "hold")
+	return nil
+}
+
+func (m *Motor) CoastStopAction() error {
+	utilities.WriteStringValue(m.folder, stopActionFD, "coast")
+	return nil
+}
+
+// State reads the raw state of the motor.
+func (m *Motor) State() (string, error) {
+	return utilities.ReadStringValue(m.folder, stateGetter), nil
 }
@@ -0,0 +1,94 @@
+package Motor
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ldmberman/GoEV3/utilities"
+)
+
+const (
+	timeSetterFD = "time_sp"
+)
+
+const degreesPerRotation = 360
+
+// RunForDegrees runs the motor at port for exactly degrees of relative
+// rotation at the given speed, then stops it with the hold stop action if
+// brake is true, or the coast stop action otherwise. If blocking is true, it waits until the motor
+// reports it is no longer running or ramping before returning.
+func RunForDegrees(port OutPort, speed int16, degrees int32, brake, blocking bool) error {
+	folder, err := resolveFolder(port)
+	if err != nil {
+		return err
+	}
+
+	setStopAction(folder, brake)
+	utilities.WriteIntValue(folder, positionSetterFD, int64(degrees))
+	utilities.WriteIntValue(folder, speedSetterFD, int64(speed))
+	utilities.WriteStringValue(folder, runFD, "run-to-rel-pos")
+
+	if blocking {
+		return waitUntilStopped(folder, 0)
+	}
+	return nil
+}
+
+// RunForRotations runs the motor at port for exactly rotations full turns
+// at the given speed. See RunForDegrees for brake and blocking.
+func RunForRotations(port OutPort, speed int16, rotations float64, brake, blocking bool) error {
+	return RunForDegrees(port, speed, int32(rotations*degreesPerRotation), brake, blocking)
+}
+
+// RunForDuration runs the motor at port for d before it stops itself, with
+// the hold stop action if brake is true, or coast otherwise. It returns as soon as the
+// command is issued; use WaitUntilStopped to wait for the motor to finish.
+func RunForDuration(port OutPort, speed int16, d time.Duration, brake bool) error {
+	folder, err := resolveFolder(port)
+	if err != nil {
+		return err
+	}
+
+	setStopAction(folder, brake)
+	utilities.WriteIntValue(folder, timeSetterFD, int64(d/time.Millisecond))
+	utilities.WriteIntValue(folder, speedSetterFD, int64(speed))
+	utilities.WriteStringValue(folder, runFD, "run-timed")
+	return nil
+}
+
+// WaitUntilStopped polls the state of the motor at port until it no longer
+// reports running or ramping, or until timeout elapses. A zero timeout
+// means wait indefinitely. It lets callers compose their own
+// move-then-wait patterns on top of the non-blocking helpers above.
+func WaitUntilStopped(port OutPort, timeout time.Duration) error {
+	folder, err := resolveFolder(port)
+	if err != nil {
+		return err
+	}
+	return waitUntilStopped(folder, timeout)
+}
+
+func waitUntilStopped(folder string, timeout time.Duration) error {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	for {
+		state := utilities.ReadStringValue(folder, stateGetter)
+		if !strings.Contains(state, "running") && !strings.Contains(state, "ramping") {
+			return nil
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return nil
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func setStopAction(folder string, brake bool) {
+	action := "coast"
+	if brake {
+		action = "hold"
+	}
+	utilities.WriteStringValue(folder, stopActionFD, action)
+}
@@ -0,0 +1,262 @@
+// Provides a software PID controller on top of Motor's sysfs primitives,
+// for use when the built-in ev3dev regulator isn't enough, e.g. holding
+// position against load or syncing two motors.
+package pid
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ldmberman/GoEV3/Motor"
+)
+
+// Controller is a PID controller that samples a motor at SampleRate and
+// writes a corrective speed back to it.
+type Controller struct {
+	Kp, Ki, Kd float64
+
+	// IMax clamps the accumulated integral term to keep it from winding
+	// up while the motor is saturated or blocked.
+	IMax float64
+	// OutMax clamps the speed written back to the motor.
+	OutMax float64
+
+	SampleRate time.Duration
+}
+
+// HoldPosition drives the motor at port to hold target, sampling
+// Motor.CurrentPosition and correcting at c.SampleRate until the returned
+// stop function is called.
+func (c *Controller) HoldPosition(port Motor.OutPort, target int32) (stop func()) {
+	return c.run(port, float64(target), func() (float64, error) {
+		pos, err := Motor.CurrentPosition(port)
+		return float64(pos), err
+	})
+}
+
+// TrackVelocity drives the motor at port to hold targetDegPerSec, sampling
+// Motor.CurrentSpeed and correcting at c.SampleRate until the returned stop
+// function is called.
+func (c *Controller) TrackVelocity(port Motor.OutPort, targetDegPerSec float64) (stop func()) {
+	return c.run(port, targetDegPerSec, func() (float64, error) {
+		speed, err := Motor.CurrentSpeed(port)
+		return float64(speed), err
+	})
+}
+
+// run starts the sampling goroutine shared by HoldPosition and
+// TrackVelocity. It derivates on the measurement rather than the error to
+// avoid derivative kick.
+func (c *Controller) run(port Motor.OutPort, target float64, measure func() (float64, error)) (stop func()) {
+	if c.SampleRate <= 0 {
+		c.SampleRate = 20 * time.Millisecond
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(c.SampleRate)
+		defer ticker.Stop()
+
+		var integral, prevMeasured float64
+		first := true
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				measured, err := measure()
+				if err != nil {
+					continue
+				}
+
+				dt := c.SampleRate.Seconds()
+				e := target - measured
+				integral = clamp(integral+e*dt, c.IMax)
+
+				var derivative float64
+				if !first {
+					derivative = (measured - prevMeasured) / dt
+				}
+				first = false
+				prevMeasured = measured
+
+				u := c.Kp*e + c.Ki*integral + c.Kd*derivative
+				u = clamp(u, c.OutMax)
+
+				Motor.Run(port, int16(u))
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+func clamp(v, max float64) float64 {
+	if v > max {
+		return max
+	}
+	if v < -max {
+		return -max
+	}
+	return v
+}
+
+// Tune runs a Ziegler-Nichols relay-feedback autotune against the motor at
+// port and sets c.Kp, c.Ki and c.Kd from the measured oscillation.
+//
+// It drives the motor at ±relayAmplitude, flipping sign every time the
+// position error changes sign, until it has observed cycles full
+// oscillations. It then measures the oscillation period Tu and amplitude a,
+// derives the ultimate gain Ku = 4*relayAmplitude/(pi*a), and sets
+// Kp = 0.6*Ku, Ki = 1.2*Ku/Tu, Kd = 0.075*Ku*Tu.
+func (c *Controller) Tune(port Motor.OutPort, target int32, relayAmplitude int16, cycles int) error {
+	if c.SampleRate <= 0 {
+		c.SampleRate = 20 * time.Millisecond
+	}
+	if cycles <= 0 {
+		cycles = 5
+	}
+
+	pos, err := Motor.CurrentPosition(port)
+	if err != nil {
+		return err
+	}
+
+	tuner := newRelayTuner(float64(target), relayAmplitude)
+	tuner.sample(float64(pos), cycles)
+
+	if err := Motor.Run(port, tuner.output()); err != nil {
+		return err
+	}
+	defer Motor.Stop(port)
+
+	for {
+		time.Sleep(c.SampleRate)
+
+		pos, err := Motor.CurrentPosition(port)
+		if err != nil {
+			return err
+		}
+
+		switched, done := tuner.sample(float64(pos), cycles)
+		if switched {
+			if err := Motor.Run(port, tuner.output()); err != nil {
+				return err
+			}
+		}
+		if done {
+			break
+		}
+	}
+
+	tu, a, err := tuner.ultimateGainPeriod(c.SampleRate)
+	if err != nil {
+		return err
+	}
+	if a == 0 {
+		return fmt.Errorf("pid: observed zero oscillation amplitude")
+	}
+
+	ku := 4 * float64(relayAmplitude) / (math.Pi * a)
+	c.Kp = 0.6 * ku
+	c.Ki = 1.2 * ku / tu.Seconds()
+	c.Kd = 0.075 * ku * tu.Seconds()
+	return nil
+}
+
+// relayTuner runs the relay-feedback oscillation detection behind Tune. It
+// holds no reference to a motor, so the oscillation detection and
+// amplitude/period measurement can be driven by a deterministic sequence
+// of simulated samples in tests.
+type relayTuner struct {
+	target         float64
+	relayAmplitude int16
+
+	sign    int16
+	prevErr float64
+	started bool
+
+	high, low      float64
+	samplesInCycle int
+
+	// amplitudes and cycleSamples record one entry per completed
+	// half-cycle: half the peak-to-peak swing observed during it, and how
+	// many samples it lasted.
+	amplitudes   []float64
+	cycleSamples []int
+}
+
+func newRelayTuner(target float64, relayAmplitude int16) *relayTuner {
+	return &relayTuner{target: target, relayAmplitude: relayAmplitude, sign: 1}
+}
+
+// output is the signed relay amplitude to command the motor with.
+func (r *relayTuner) output() int16 {
+	return r.sign * r.relayAmplitude
+}
+
+// sample feeds one position measurement into the tuner. It flips the
+// relay sign whenever the position error changes sign, recording the
+// amplitude and duration of the half-cycle that just ended. switched
+// reports whether this sample caused a flip; done reports whether cycles
+// full oscillations (2*cycles half-cycles) have now been observed.
+func (r *relayTuner) sample(measured float64, cycles int) (switched, done bool) {
+	if !r.started {
+		r.started = true
+		r.prevErr = r.target - measured
+		r.high, r.low = measured, measured
+		return false, false
+	}
+
+	if measured > r.high {
+		r.high = measured
+	}
+	if measured < r.low {
+		r.low = measured
+	}
+	r.samplesInCycle++
+
+	e := r.target - measured
+	if (e >= 0) != (r.prevErr >= 0) {
+		r.sign = -r.sign
+		r.amplitudes = append(r.amplitudes, (r.high-r.low)/2)
+		r.cycleSamples = append(r.cycleSamples, r.samplesInCycle)
+		r.high, r.low = measured, measured
+		r.samplesInCycle = 0
+		switched = true
+	}
+	r.prevErr = e
+
+	return switched, len(r.amplitudes) >= cycles*2
+}
+
+// ultimateGainPeriod averages every recorded half-cycle's amplitude, and
+// every recorded full period's (two half-cycles') sample count, returning
+// the ultimate period Tu and amplitude a used to compute Ku.
+func (r *relayTuner) ultimateGainPeriod(sampleRate time.Duration) (tu time.Duration, a float64, err error) {
+	if len(r.amplitudes) < 3 || len(r.cycleSamples) < 3 {
+		return 0, 0, fmt.Errorf("pid: not enough oscillation cycles observed to tune")
+	}
+
+	var totalSamples, n int
+	for i := 1; i < len(r.cycleSamples); i += 2 {
+		totalSamples += r.cycleSamples[i-1] + r.cycleSamples[i]
+		n++
+	}
+	if n == 0 {
+		return 0, 0, fmt.Errorf("pid: not enough oscillation cycles observed to tune")
+	}
+
+	var totalAmplitude float64
+	for _, amp := range r.amplitudes {
+		totalAmplitude += amp
+	}
+
+	avgSamplesPerPeriod := float64(totalSamples) / float64(n)
+	tu = time.Duration(avgSamplesPerPeriod * float64(sampleRate))
+	a = totalAmplitude / float64(len(r.amplitudes))
+	return tu, a, nil
+}
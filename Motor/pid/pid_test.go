@@ -0,0 +1,77 @@
+package pid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClamp(t *testing.T) {
+	cases := []struct {
+		v, max, want float64
+	}{
+		{5, 10, 5},
+		{15, 10, 10},
+		{-15, 10, -10},
+		{0, 10, 0},
+	}
+	for _, c := range cases {
+		if got := clamp(c.v, c.max); got != c.want {
+			t.Errorf("clamp(%v, %v) = %v, want %v", c.v, c.max, got, c.want)
+		}
+	}
+}
+
+// TestRelayTunerOscillation drives a relayTuner with a deterministic
+// sequence of simulated positions oscillating around target=0 and checks
+// that it records the amplitude of every half-cycle (rather than only the
+// last one) and averages them correctly, along with the full-period
+// sample counts used to derive Tu.
+func TestRelayTunerOscillation(t *testing.T) {
+	tuner := newRelayTuner(0, 50)
+	samples := []float64{0, 0, 10, -10, 0, 10, -10}
+
+	var gotDone bool
+	for i, s := range samples {
+		_, done := tuner.sample(s, 2)
+		if done {
+			gotDone = true
+			if i != len(samples)-1 {
+				t.Fatalf("tuner reported done after sample %d, want after the last sample", i)
+			}
+		}
+	}
+	if !gotDone {
+		t.Fatalf("tuner never reported done after %d samples", len(samples))
+	}
+
+	wantAmplitudes := []float64{5, 10, 10, 10}
+	if len(tuner.amplitudes) != len(wantAmplitudes) {
+		t.Fatalf("amplitudes = %v, want %v", tuner.amplitudes, wantAmplitudes)
+	}
+	for i, want := range wantAmplitudes {
+		if tuner.amplitudes[i] != want {
+			t.Errorf("amplitudes[%d] = %v, want %v", i, tuner.amplitudes[i], want)
+		}
+	}
+
+	tu, a, err := tuner.ultimateGainPeriod(10 * time.Millisecond)
+	if err != nil {
+		t.Fatalf("ultimateGainPeriod returned error: %v", err)
+	}
+	if tu != 30*time.Millisecond {
+		t.Errorf("tu = %v, want 30ms", tu)
+	}
+	if a != 8.75 {
+		t.Errorf("a = %v, want 8.75 (mean of %v)", a, wantAmplitudes)
+	}
+}
+
+func TestUltimateGainPeriodNotEnoughCycles(t *testing.T) {
+	tuner := newRelayTuner(0, 50)
+	tuner.sample(0, 5)
+	tuner.sample(10, 5)
+
+	if _, _, err := tuner.ultimateGainPeriod(10 * time.Millisecond); err == nil {
+		t.Fatal("ultimateGainPeriod: expected error with fewer than 3 recorded half-cycles")
+	}
+}
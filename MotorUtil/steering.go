@@ -0,0 +1,154 @@
+// Provides a differential-drive steering helper built on top of the Motor package.
+package MotorUtil
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ldmberman/GoEV3/Motor"
+)
+
+// Steering drives a pair of motors mounted on opposite sides of a robot as
+// a differential ("tank") drive, turning between them using a single
+// direction parameter instead of two independent speeds.
+type Steering struct {
+	Left, Right Motor.OutPort
+
+	// Timeout bounds how long SteerDegrees waits for both motors to leave
+	// the running/ramping state. Zero means wait indefinitely.
+	Timeout time.Duration
+}
+
+// NewSteering creates a Steering for the motors connected to left and right.
+func NewSteering(left, right Motor.OutPort) *Steering {
+	return &Steering{Left: left, Right: right}
+}
+
+// SteerForever drives both motors at the given direction and speed until
+// explicitly stopped.
+//
+// direction ranges from -100 (hard left, spin in place) through 0 (both
+// motors forward at equal speed) to +100 (hard right, spin in place).
+func (s *Steering) SteerForever(direction, speed int) error {
+	left, right := SteeringSpeeds(direction, speed)
+	if err := Motor.Run(s.Left, int16(left)); err != nil {
+		return err
+	}
+	return Motor.Run(s.Right, int16(right))
+}
+
+// SteerDuration drives both motors at the given direction and speed for d,
+// then stops them.
+func (s *Steering) SteerDuration(direction, speed int, d time.Duration) error {
+	if err := s.SteerForever(direction, speed); err != nil {
+		return err
+	}
+	time.Sleep(d)
+	if err := Motor.Stop(s.Left); err != nil {
+		return err
+	}
+	return Motor.Stop(s.Right)
+}
+
+// SteerDegrees turns both motors by degrees at the given direction and
+// speed, scaling the inside motor's relative position setpoint so both
+// motors reach their targets at roughly the same time, then waits for both
+// to stop.
+func (s *Steering) SteerDegrees(direction, speed, degrees int) error {
+	leftCounts, rightCounts := SteeringCounts(direction, int32(degrees))
+	leftSpeed, rightSpeed := SteeringSpeeds(direction, speed)
+
+	if err := Motor.RunForDegrees(s.Left, int16(leftSpeed), leftCounts, true, false); err != nil {
+		return err
+	}
+	if err := Motor.RunForDegrees(s.Right, int16(rightSpeed), rightCounts, true, false); err != nil {
+		return err
+	}
+
+	return s.waitUntilStopped()
+}
+
+func (s *Steering) waitUntilStopped() error {
+	var deadline time.Time
+	if s.Timeout > 0 {
+		deadline = time.Now().Add(s.Timeout)
+	}
+	for {
+		leftState, err := Motor.GetState(s.Left)
+		if err != nil {
+			return err
+		}
+		rightState, err := Motor.GetState(s.Right)
+		if err != nil {
+			return err
+		}
+		if !isBusy(leftState) && !isBusy(rightState) {
+			return nil
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return nil
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func isBusy(state string) bool {
+	return strings.Contains(state, "running") || strings.Contains(state, "ramping")
+}
+
+// SteeringSpeeds maps a direction in [-100, 100] and a base speed to the
+// left and right motor speeds for a differential drive.
+//
+// For |direction| <= 50 the inside wheel is scaled by 1 - 2*|direction|/100,
+// slowing it while keeping its sign. For |direction| > 50 the inside wheel
+// is reversed and scaled by (2*|direction| - 100)/100, so the extremes
+// counter-rotate the wheels for a pivot turn. The outside wheel always runs
+// at speed.
+func SteeringSpeeds(direction, speed int) (left, right int) {
+	d := clamp(direction, -100, 100)
+
+	abs := d
+	if abs < 0 {
+		abs = -abs
+	}
+
+	reverseInside := abs > 50
+	var insideScale float64
+	if reverseInside {
+		insideScale = float64(2*abs-100) / 100
+	} else {
+		insideScale = 1 - float64(2*abs)/100
+	}
+
+	inside := int(float64(speed) * insideScale)
+	if reverseInside {
+		inside = -inside
+	}
+
+	if d >= 0 {
+		// Turning right: the right wheel is on the inside of the turn.
+		return speed, inside
+	}
+	return inside, speed
+}
+
+// SteeringCounts maps a direction and a base position delta in degrees to
+// the per-motor position deltas for SteerDegrees, scaling the inside
+// motor's counts by the same ratio as its speed so both motors arrive at
+// their targets together.
+func SteeringCounts(direction int, degrees int32) (left, right int32) {
+	leftRatio, rightRatio := SteeringSpeeds(direction, 100)
+	left = int32(float64(degrees) * float64(leftRatio) / 100)
+	right = int32(float64(degrees) * float64(rightRatio) / 100)
+	return
+}
+
+func clamp(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
@@ -0,0 +1,63 @@
+package MotorUtil
+
+import "testing"
+
+func TestSteeringSpeedsStraight(t *testing.T) {
+	left, right := SteeringSpeeds(0, 80)
+	if left != 80 || right != 80 {
+		t.Fatalf("SteeringSpeeds(0, 80) = (%d, %d), want (80, 80)", left, right)
+	}
+}
+
+func TestSteeringSpeedsGentleRight(t *testing.T) {
+	left, right := SteeringSpeeds(30, 100)
+	if left != 100 {
+		t.Errorf("left = %d, want 100 (outside wheel keeps speed)", left)
+	}
+	if right != 40 {
+		t.Errorf("right = %d, want 40 (inside wheel scaled by 1-2*30/100)", right)
+	}
+}
+
+func TestSteeringSpeedsPivotRight(t *testing.T) {
+	left, right := SteeringSpeeds(100, 100)
+	if left != 100 || right != -100 {
+		t.Fatalf("SteeringSpeeds(100, 100) = (%d, %d), want (100, -100)", left, right)
+	}
+}
+
+func TestSteeringSpeedsPivotLeft(t *testing.T) {
+	left, right := SteeringSpeeds(-100, 100)
+	if left != -100 || right != 100 {
+		t.Fatalf("SteeringSpeeds(-100, 100) = (%d, %d), want (-100, 100)", left, right)
+	}
+}
+
+func TestSteeringSpeedsClampsDirection(t *testing.T) {
+	left, right := SteeringSpeeds(1000, 100)
+	wantLeft, wantRight := SteeringSpeeds(100, 100)
+	if left != wantLeft || right != wantRight {
+		t.Fatalf("SteeringSpeeds(1000, 100) = (%d, %d), want clamped to (%d, %d)", left, right, wantLeft, wantRight)
+	}
+}
+
+func TestSteeringCountsStraight(t *testing.T) {
+	left, right := SteeringCounts(0, 360)
+	if left != 360 || right != 360 {
+		t.Fatalf("SteeringCounts(0, 360) = (%d, %d), want (360, 360)", left, right)
+	}
+}
+
+func TestSteeringCountsPivotRight(t *testing.T) {
+	left, right := SteeringCounts(100, 360)
+	if left != 360 || right != -360 {
+		t.Fatalf("SteeringCounts(100, 360) = (%d, %d), want (360, -360)", left, right)
+	}
+}
+
+func TestSteeringCountsGentleRight(t *testing.T) {
+	left, right := SteeringCounts(30, 360)
+	if left != 360 || right != 144 {
+		t.Fatalf("SteeringCounts(30, 360) = (%d, %d), want (360, 144)", left, right)
+	}
+}